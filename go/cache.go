@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheEntry is the on-disk representation of one release's parsed
+// interpreter index.
+type CacheEntry struct {
+	ReleaseTag   string        `json:"release_tag"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	CachedAt     time.Time     `json:"cached_at"`
+	Interpreters []Interpreter `json:"interpreters"`
+}
+
+// Cache stores parsed interpreter indexes on disk, one JSON file per release
+// tag, so GetInterpretersCached doesn't need to re-fetch every asset's
+// PYTHON.json on every run.
+type Cache struct {
+	dir string
+}
+
+// defaultCacheDir returns $XDG_CACHE_HOME/rez-bootstrap/index (or the
+// platform equivalent, e.g. %LOCALAPPDATA% on Windows) via os.UserCacheDir.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "rez-bootstrap", "index")
+}
+
+// NewCache opens the on-disk interpreter index cache.
+func NewCache() *Cache {
+	return &Cache{dir: defaultCacheDir()}
+}
+
+func (c *Cache) path(releaseTag string) string {
+	return filepath.Join(c.dir, releaseTag+".json")
+}
+
+// Get returns the cached entry for releaseTag, if one exists.
+func (c *Cache) Get(releaseTag string) (CacheEntry, bool) {
+	var entry CacheEntry
+
+	if c.dir == "" {
+		return entry, false
+	}
+
+	data, err := os.ReadFile(c.path(releaseTag))
+	if err != nil {
+		return entry, false
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// Fresh reports whether the cached entry for releaseTag exists and still
+// matches the release's current UpdatedAt.
+func (c *Cache) Fresh(releaseTag string, updatedAt time.Time) bool {
+	entry, ok := c.Get(releaseTag)
+	return ok && entry.UpdatedAt.Equal(updatedAt)
+}
+
+// Put stores interpreters for releaseTag, stamped with the release's
+// UpdatedAt so Fresh can later tell whether the entry is stale.
+func (c *Cache) Put(releaseTag string, updatedAt time.Time, interpreters []Interpreter) error {
+	if c.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", c.dir, err)
+	}
+
+	entry := CacheEntry{
+		ReleaseTag:   releaseTag,
+		UpdatedAt:    updatedAt,
+		CachedAt:     time.Now(),
+		Interpreters: interpreters,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry for %q: %w", releaseTag, err)
+	}
+
+	return os.WriteFile(c.path(releaseTag), data, 0o644)
+}
+
+// Prune removes cached entries last written more than maxAge ago, to bound
+// disk usage.
+func (c *Cache) Prune(maxAge time.Duration) error {
+	if c.dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cache directory %s: %w", c.dir, err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(c.dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var cached CacheEntry
+		if err := json.Unmarshal(data, &cached); err != nil {
+			continue
+		}
+
+		if cached.CachedAt.Before(cutoff) {
+			os.Remove(path)
+		}
+	}
+
+	return nil
+}
+
+// GetInterpretersCached behaves like GetInterpreters, but consults cache
+// first and skips the expensive per-asset PYTHON.json fetches for any
+// release whose cached entry is still fresh. Pass refresh to force
+// revalidation even when the cache looks fresh.
+func GetInterpretersCached(cache *Cache, threads int, refresh bool, releases ...GitHubRelease) ([]Interpreter, error) {
+	var all []Interpreter
+
+	for _, release := range releases {
+		if !refresh && cache.Fresh(release.TagName, release.UpdatedAt) {
+			entry, _ := cache.Get(release.TagName)
+			all = append(all, entry.Interpreters...)
+			continue
+		}
+
+		interpreters, err := GetInterpreters(threads, release)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get interpreters for release %q: %w", release.TagName, err)
+		}
+
+		if err := cache.Put(release.TagName, release.UpdatedAt, interpreters); err != nil {
+			return nil, fmt.Errorf("failed to cache release %q: %w", release.TagName, err)
+		}
+
+		all = append(all, interpreters...)
+	}
+
+	return all, nil
+}
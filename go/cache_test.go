@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheGetPutRoundTrip(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+
+	updatedAt := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	interpreters := []Interpreter{{Implementation: "cpython", PythonVersion: "3.12.2"}}
+
+	if err := cache.Put("20240415", updatedAt, interpreters); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	entry, ok := cache.Get("20240415")
+	if !ok {
+		t.Fatal("Get: ok = false, want true")
+	}
+	if !entry.UpdatedAt.Equal(updatedAt) {
+		t.Fatalf("entry.UpdatedAt = %v, want %v", entry.UpdatedAt, updatedAt)
+	}
+	if len(entry.Interpreters) != 1 || entry.Interpreters[0].PythonVersion != "3.12.2" {
+		t.Fatalf("entry.Interpreters = %+v", entry.Interpreters)
+	}
+}
+
+func TestCacheGetMissingEntry(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+
+	if _, ok := cache.Get("does-not-exist"); ok {
+		t.Fatal("Get: ok = true for a missing entry, want false")
+	}
+}
+
+func TestCacheFresh(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+	updatedAt := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	if cache.Fresh("20240415", updatedAt) {
+		t.Fatal("Fresh = true before any entry is cached, want false")
+	}
+
+	if err := cache.Put("20240415", updatedAt, nil); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if !cache.Fresh("20240415", updatedAt) {
+		t.Fatal("Fresh = false for a matching UpdatedAt, want true")
+	}
+
+	staleUpdatedAt := updatedAt.Add(time.Hour)
+	if cache.Fresh("20240415", staleUpdatedAt) {
+		t.Fatal("Fresh = true for a newer UpdatedAt than what's cached, want false")
+	}
+}
+
+func TestCachePrune(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+
+	if err := cache.Put("old", time.Now(), nil); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := cache.Put("fresh", time.Now(), nil); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	// Backdate the "old" entry's CachedAt directly on disk since Put always
+	// stamps it with time.Now().
+	oldPath := cache.path("old")
+	data, err := os.ReadFile(oldPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	backdated := time.Now().Add(-48 * time.Hour).Format(time.RFC3339Nano)
+	rewritten := replaceCachedAt(t, string(data), backdated)
+	if err := os.WriteFile(oldPath, []byte(rewritten), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := cache.Prune(24 * time.Hour); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Fatalf("old entry should have been pruned, stat err = %v", err)
+	}
+	if _, err := os.Stat(cache.path("fresh")); err != nil {
+		t.Fatalf("fresh entry should survive Prune: %v", err)
+	}
+}
+
+// replaceCachedAt rewrites the cached_at field in a marshaled CacheEntry's
+// JSON, since Cache.Put always stamps CachedAt with time.Now().
+func replaceCachedAt(t *testing.T, data string, newValue string) string {
+	t.Helper()
+
+	var entry CacheEntry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	newTime, err := time.Parse(time.RFC3339Nano, newValue)
+	if err != nil {
+		t.Fatalf("time.Parse: %v", err)
+	}
+	entry.CachedAt = newTime
+
+	out, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return string(out)
+}
+
+func TestGetInterpretersCachedSkipsFreshRelease(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+	updatedAt := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	cached := []Interpreter{{Implementation: "cpython", PythonVersion: "3.12.2"}}
+
+	if err := cache.Put("20240415", updatedAt, cached); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	release := GitHubRelease{TagName: "20240415", UpdatedAt: updatedAt}
+
+	// release.Assets is empty, so if GetInterpretersCached didn't honor the
+	// fresh-cache hit it would still succeed (GetInterpreters needs no
+	// network for zero assets) but would return no interpreters instead of
+	// the cached ones, failing the assertion below.
+	got, err := GetInterpretersCached(cache, 1, false, release)
+	if err != nil {
+		t.Fatalf("GetInterpretersCached: %v", err)
+	}
+	if len(got) != 1 || got[0].PythonVersion != "3.12.2" {
+		t.Fatalf("got %+v, want the cached entry returned without a re-fetch", got)
+	}
+}
+
+func TestGetInterpretersCachedRefetchesStaleRelease(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+	oldUpdatedAt := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	newUpdatedAt := oldUpdatedAt.Add(time.Hour)
+
+	if err := cache.Put("20240415", oldUpdatedAt, []Interpreter{{PythonVersion: "stale"}}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	release := GitHubRelease{TagName: "20240415", UpdatedAt: newUpdatedAt}
+
+	got, err := GetInterpretersCached(cache, 1, false, release)
+	if err != nil {
+		t.Fatalf("GetInterpretersCached: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %+v, want no interpreters (release has no assets)", got)
+	}
+
+	entry, ok := cache.Get("20240415")
+	if !ok {
+		t.Fatal("Get: ok = false after refresh, want true")
+	}
+	if !entry.UpdatedAt.Equal(newUpdatedAt) {
+		t.Fatalf("entry.UpdatedAt = %v, want %v (cache should have been overwritten)", entry.UpdatedAt, newUpdatedAt)
+	}
+}
+
+func TestGetInterpretersCachedForceRefreshBypassesFreshCache(t *testing.T) {
+	cache := &Cache{dir: t.TempDir()}
+	updatedAt := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	if err := cache.Put("20240415", updatedAt, []Interpreter{{PythonVersion: "stale-but-fresh"}}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	release := GitHubRelease{TagName: "20240415", UpdatedAt: updatedAt}
+
+	got, err := GetInterpretersCached(cache, 1, true, release)
+	if err != nil {
+		t.Fatalf("GetInterpretersCached: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %+v, want the release re-fetched instead of the cached hit", got)
+	}
+
+	entry, ok := cache.Get("20240415")
+	if !ok {
+		t.Fatal("Get: ok = false after forced refresh, want true")
+	}
+	if len(entry.Interpreters) != 0 {
+		t.Fatalf("entry.Interpreters = %+v, want cache overwritten by the forced refresh", entry.Interpreters)
+	}
+}
+
+func TestCachePath(t *testing.T) {
+	cache := &Cache{dir: "/tmp/rez-bootstrap-cache"}
+	want := filepath.Join("/tmp/rez-bootstrap-cache", "20240415.json")
+	if got := cache.path("20240415"); got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+}
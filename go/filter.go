@@ -0,0 +1,107 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/JeanChristopheMorinPerso/rez-bootstrap/semver"
+)
+
+// FilterSpec describes which interpreters Filter should keep.
+type FilterSpec struct {
+	// Spec is a space-separated semver range, e.g. ">=3.10.0 <3.13",
+	// "~3.11", or "==3.12.*". Empty means no version constraint.
+	Spec string
+
+	// Implementation restricts matches to this implementation (e.g.
+	// "cpython", "pypy"), case-insensitively. Empty matches any.
+	Implementation string
+
+	// Flavor, if set, restricts matches to this InterpreterFlavor.
+	Flavor *InterpreterFlavor
+
+	// MinConfig, if set, requires interpreters to be at least this
+	// optimized, e.g. MinConfig pointing at LTO keeps PgoLto, PGO, and LTO
+	// builds but drops NoOpt, Empty, and Debug ones.
+	MinConfig *Config
+}
+
+// Filter keeps only the interpreters matching spec.
+func Filter(interpreters []Interpreter, spec FilterSpec) ([]Interpreter, error) {
+	var constraints []semver.Constraint
+	if spec.Spec != "" {
+		var err error
+		constraints, err = semver.ParseConstraints(spec.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filtered := make([]Interpreter, 0, len(interpreters))
+	for _, interpreter := range interpreters {
+		if len(constraints) > 0 {
+			version, err := semver.Parse(interpreter.PythonVersion)
+			if err != nil || !semver.MatchAll(constraints, version) {
+				continue
+			}
+		}
+
+		if spec.Implementation != "" && !strings.EqualFold(interpreter.Implementation, spec.Implementation) {
+			continue
+		}
+
+		if spec.Flavor != nil && interpreter.Flavor != *spec.Flavor {
+			continue
+		}
+
+		if spec.MinConfig != nil && interpreter.Config > *spec.MinConfig {
+			continue
+		}
+
+		filtered = append(filtered, interpreter)
+	}
+
+	return filtered, nil
+}
+
+// LatestPerMinor groups interpreters by implementation and MAJOR.MINOR, and
+// returns the newest patch release from each group. This is what a rez
+// bootstrap workflow typically needs to build a version matrix.
+func LatestPerMinor(interpreters []Interpreter) []Interpreter {
+	type minorKey struct {
+		Implementation string
+		Major, Minor   int
+	}
+
+	bestVersion := map[minorKey]semver.Version{}
+	best := map[minorKey]Interpreter{}
+
+	for _, interpreter := range interpreters {
+		version, err := semver.Parse(interpreter.PythonVersion)
+		if err != nil {
+			continue
+		}
+
+		key := minorKey{interpreter.Implementation, version.Major, version.Minor}
+		if current, ok := bestVersion[key]; !ok || semver.Compare(version, current) > 0 {
+			bestVersion[key] = version
+			best[key] = interpreter
+		}
+	}
+
+	result := make([]Interpreter, 0, len(best))
+	for _, interpreter := range best {
+		result = append(result, interpreter)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Implementation != result[j].Implementation {
+			return result[i].Implementation < result[j].Implementation
+		}
+		vi, _ := semver.Parse(result[i].PythonVersion)
+		vj, _ := semver.Parse(result[j].PythonVersion)
+		return semver.Compare(vi, vj) < 0
+	})
+
+	return result
+}
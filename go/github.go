@@ -1,9 +1,11 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
+	"time"
+
+	"github.com/JeanChristopheMorinPerso/rez-bootstrap/github"
 )
 
 // A GitHub release asset.
@@ -14,65 +16,125 @@ type GitHubReleaseAsset struct {
 
 // A GitHub release.
 type GitHubRelease struct {
-	Assets []GitHubReleaseAsset `json:"assets"`
+	ID          int64                `json:"id"`
+	TagName     string               `json:"tag_name"`
+	PublishedAt time.Time            `json:"published_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	Assets      []GitHubReleaseAsset `json:"assets"`
 }
 
-// Get the latest release fro GitHub.
+// releasesURL is a var rather than a const so tests can point it at an
+// httptest server.
+var releasesURL = "https://api.github.com/repos/indygreg/python-build-standalone/releases"
+
+// defaultClient is shared by every package-level function that talks to the
+// GitHub API, so authentication, rate-limiting, and the ETag cache are
+// consistent across the whole program.
+var defaultClient = github.NewClient()
+
+// GetLatestRelease fetches the latest release from GitHub.
 func GetLatestRelease() (GitHubRelease, error) {
 	var release GitHubRelease
 
-	response, err := http.Get("https://api.github.com/repos/indygreg/python-build-standalone/releases/latest")
-
-	if err != nil {
+	if err := defaultClient.GetJSON(releasesURL+"/latest", &release); err != nil {
 		return release, fmt.Errorf("failed to get latest release: %w", err)
 	}
 
-	defer response.Body.Close()
+	return release, nil
+}
 
-	if response.StatusCode != 200 {
-		return release, fmt.Errorf("failed to get latest release: %s", response.Status)
-	}
+// ReleasesOptions controls which releases GetReleases returns.
+type ReleasesOptions struct {
+	// Since, if non-zero, excludes releases published before this time.
+	Since time.Time
 
-	err = json.NewDecoder(response.Body).Decode(&release)
-	return release, err
+	// MaxPages caps how many pages of releases are fetched. Zero means no
+	// limit; GetReleases walks every page GitHub reports.
+	MaxPages int
 
+	// Predicate, if set, further filters releases; only releases for which
+	// it returns true are kept.
+	Predicate func(GitHubRelease) bool
 }
 
-// Parse parses the asset name and return an Interpreter.
-func (asset GitHubReleaseAsset) Parse() (Interpreter, error) {
-	var interpreter Interpreter
+// GetReleases walks every page of
+// /repos/indygreg/python-build-standalone/releases, following the Link:
+// rel="next" header, and returns the releases matching opts.
+func GetReleases(opts ReleasesOptions) ([]GitHubRelease, error) {
+	var releases []GitHubRelease
 
-	matches := fullRegex.FindAllStringSubmatch(asset.Name, -1)
-	if len(matches) != 1 {
-		matches = installOnlyRegex.FindAllStringSubmatch(asset.Name, -1)
+	url := releasesURL
+	for page := 0; url != ""; page++ {
+		if opts.MaxPages > 0 && page >= opts.MaxPages {
+			break
+		}
 
-		if len(matches) != 1 {
-			return interpreter, fmt.Errorf("could not parse asset name: %s", asset.Name)
+		var pageReleases []GitHubRelease
+		headers, err := defaultClient.GetJSONWithHeaders(url, &pageReleases)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get releases (page %d): %w", page+1, err)
 		}
 
-		interpreter = Interpreter{
-			Implementation: matches[0][installOnlyRegex.SubexpIndex("implementation")],
-			PythonVersion:  matches[0][installOnlyRegex.SubexpIndex("pythonVersion")],
-			GitHubRelease:  matches[0][installOnlyRegex.SubexpIndex("githubRelease")],
-			Triple:         matches[0][installOnlyRegex.SubexpIndex("triple")],
-			Flavor:         FlavorInstallOnly,
+		for _, release := range pageReleases {
+			if !opts.Since.IsZero() && release.PublishedAt.Before(opts.Since) {
+				continue
+			}
+
+			if opts.Predicate != nil && !opts.Predicate(release) {
+				continue
+			}
+
+			releases = append(releases, release)
 		}
-	} else {
-		config, err := ConfigFromString(matches[0][fullRegex.SubexpIndex("config")])
-		if err != nil {
-			return interpreter, err
+
+		url = parseLinkHeader(headers.Get("Link"))["next"]
+	}
+
+	return releases, nil
+}
+
+// GetReleaseByTag fetches a single release by its tag name (e.g.
+// "20240415"), which is useful for pinning a reproducible build.
+func GetReleaseByTag(tag string) (GitHubRelease, error) {
+	var release GitHubRelease
+
+	url := fmt.Sprintf("%s/tags/%s", releasesURL, tag)
+	if err := defaultClient.GetJSON(url, &release); err != nil {
+		return release, fmt.Errorf("failed to get release %q: %w", tag, err)
+	}
+
+	return release, nil
+}
+
+// parseLinkHeader parses a GitHub-style RFC 5988 Link header into a map of
+// rel name (e.g. "next", "last") to URL.
+func parseLinkHeader(header string) map[string]string {
+	links := map[string]string{}
+	if header == "" {
+		return links
+	}
+
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
 		}
 
-		interpreter = Interpreter{
-			Implementation: matches[0][fullRegex.SubexpIndex("implementation")],
-			PythonVersion:  matches[0][fullRegex.SubexpIndex("pythonVersion")],
-			GitHubRelease:  matches[0][fullRegex.SubexpIndex("githubRelease")],
-			Triple:         matches[0][fullRegex.SubexpIndex("triple")],
-			Config:         config,
-			Flavor:         FlavorFull,
+		url := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+
+		for _, param := range parts[1:] {
+			rel, ok := strings.CutPrefix(strings.TrimSpace(param), `rel="`)
+			if !ok {
+				continue
+			}
+			links[strings.TrimSuffix(rel, `"`)] = url
 		}
 	}
 
-	interpreter.Url = asset.BrowserDownloadURL
-	return interpreter, nil
+	return links
+}
+
+// Parse parses the asset name and return an Interpreter.
+func (asset GitHubReleaseAsset) Parse() (Interpreter, error) {
+	return parseAsset(asset)
 }
@@ -0,0 +1,261 @@
+// Package github provides a small HTTP client for the GitHub REST API that
+// handles authentication, rate-limiting, and ETag-based conditional requests
+// so callers don't have to.
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const apiVersion = "2022-11-28"
+
+// Client is a rate-limit and cache aware GitHub API client.
+//
+// The zero value is not usable; construct one with NewClient.
+type Client struct {
+	HTTPClient *http.Client
+
+	token    string
+	username string
+
+	// FailFast makes Get return an error as soon as the rate limit is
+	// exhausted instead of sleeping until it resets.
+	FailFast bool
+
+	cachePath string
+}
+
+// cacheEntry is the on-disk representation of a cached response, keyed by
+// request URL in the cache file.
+type cacheEntry struct {
+	ETag   string          `json:"etag"`
+	Body   json.RawMessage `json:"body"`
+	Header http.Header     `json:"header"`
+}
+
+// NewClient builds a Client configured from the environment. GITHUB_TOKEN is
+// used for bearer authentication when set; otherwise GITHUB_USER, if set,
+// is used for basic authentication. Unauthenticated requests are allowed but
+// are subject to GitHub's much lower rate limit.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		token:      os.Getenv("GITHUB_TOKEN"),
+		username:   os.Getenv("GITHUB_USER"),
+		cachePath:  defaultCachePath(),
+	}
+}
+
+func defaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "rez-bootstrap", "http-cache.json")
+}
+
+// Get issues an authenticated GET request, honoring the rate limit reported
+// by the API. Callers are responsible for closing the returned response
+// body.
+func (c *Client) Get(url string) (*http.Response, error) {
+	return c.do(http.MethodGet, url, nil)
+}
+
+// Head issues an authenticated HEAD request, e.g. to check Accept-Ranges and
+// Content-Length before attempting range requests. Callers are responsible
+// for closing the returned response body.
+func (c *Client) Head(url string) (*http.Response, error) {
+	return c.do(http.MethodHead, url, nil)
+}
+
+// GetRange issues an authenticated GET request for a single HTTP byte range,
+// honoring the rate limit reported by the API. Callers are responsible for
+// closing the returned response body.
+func (c *Client) GetRange(url string, rangeHeader string) (*http.Response, error) {
+	return c.do(http.MethodGet, url, map[string]string{"Range": rangeHeader})
+}
+
+// GetJSON issues an authenticated GET request against url and decodes the
+// JSON response body into v. If a previous call cached an ETag for url, the
+// request is sent with If-None-Match, and when the server responds with 304
+// Not Modified the cached body is decoded into v instead of re-downloading it.
+func (c *Client) GetJSON(url string, v interface{}) error {
+	_, err := c.GetJSONWithHeaders(url, v)
+	return err
+}
+
+// GetJSONWithHeaders behaves like GetJSON but also returns the response
+// headers, which callers need for pagination (the Link header). On a cache
+// hit the headers from the cached response are returned.
+func (c *Client) GetJSONWithHeaders(url string, v interface{}) (http.Header, error) {
+	cache := c.loadCache()
+	entry, cached := cache[url]
+
+	etag := ""
+	if cached {
+		etag = entry.ETag
+	}
+
+	headers := map[string]string{}
+	if etag != "" {
+		headers["If-None-Match"] = etag
+	}
+
+	response, err := c.do(http.MethodGet, url, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified {
+		if !cached {
+			return nil, fmt.Errorf("got 304 Not Modified for %q but no cached body is available", url)
+		}
+		return entry.Header, json.Unmarshal(entry.Body, v)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for %q: %w", url, err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON response for %q: %w", url, err)
+	}
+
+	if newEtag := response.Header.Get("ETag"); newEtag != "" {
+		cache[url] = cacheEntry{ETag: newEtag, Body: body, Header: response.Header}
+		c.saveCache(cache)
+	}
+
+	return response.Header, nil
+}
+
+// okStatus reports whether status is an expected success response for any of
+// the request kinds do() is used for (plain GET/HEAD, conditional GET, and
+// ranged GET).
+func okStatus(status int) bool {
+	switch status {
+	case http.StatusOK, http.StatusNotModified, http.StatusPartialContent:
+		return true
+	default:
+		return false
+	}
+}
+
+// do performs the actual request, setting auth and API headers and retrying
+// once the rate limit window has reset.
+func (c *Client) do(method string, url string, headers map[string]string) (*http.Response, error) {
+	for {
+		request, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request for %q: %w", url, err)
+		}
+
+		request.Header.Set("Accept", "application/vnd.github+json")
+		request.Header.Set("X-GitHub-Api-Version", apiVersion)
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		switch {
+		case c.token != "":
+			request.Header.Set("Authorization", "Bearer "+c.token)
+		case c.username != "":
+			request.SetBasicAuth(c.username, "")
+		}
+
+		response, err := c.HTTPClient.Do(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %q: %w", url, err)
+		}
+
+		wait, retry := c.rateLimitWait(response)
+		if !retry {
+			if !okStatus(response.StatusCode) {
+				response.Body.Close()
+				return nil, fmt.Errorf("request to %q failed: %s", url, response.Status)
+			}
+			return response, nil
+		}
+
+		response.Body.Close()
+		if c.FailFast {
+			return nil, fmt.Errorf("rate limit exceeded for %q, resets in %s", url, wait)
+		}
+		time.Sleep(wait)
+	}
+}
+
+// rateLimitWait inspects the rate limit headers on response and reports how
+// long to sleep before retrying, if the limit has been exhausted.
+func (c *Client) rateLimitWait(response *http.Response) (time.Duration, bool) {
+	if response.StatusCode != http.StatusForbidden && response.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if response.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+
+	reset := response.Header.Get("X-RateLimit-Reset")
+	if reset == "" {
+		return 0, false
+	}
+
+	unix, err := parseUnixSeconds(reset)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := time.Until(time.Unix(unix, 0))
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+func parseUnixSeconds(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func (c *Client) loadCache() map[string]cacheEntry {
+	cache := map[string]cacheEntry{}
+
+	if c.cachePath == "" {
+		return cache
+	}
+
+	data, err := os.ReadFile(c.cachePath)
+	if err != nil {
+		return cache
+	}
+
+	// A corrupt cache file is not fatal; just start fresh.
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+func (c *Client) saveCache(cache map[string]cacheEntry) {
+	if c.cachePath == "" {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.cachePath), 0o755); err != nil {
+		return
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(c.cachePath, data, 0o644)
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		cachePath:  filepath.Join(t.TempDir(), "http-cache.json"),
+	}
+}
+
+func TestGetRetriesAfterRateLimitResets(t *testing.T) {
+	var requests int
+	// X-RateLimit-Reset is whole Unix seconds, so padding by just
+	// one sub-second duration risks the truncated reset time already being
+	// in the past; two seconds keeps the wait comfortably positive.
+	resetAt := time.Now().Add(2 * time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t)
+
+	start := time.Now()
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer response.Body.Close()
+
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (one rate-limited, one retry)", requests)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Fatalf("Get returned after %s, want it to have waited for the rate limit reset", elapsed)
+	}
+}
+
+func TestGetFailFastReturnsErrorInsteadOfWaiting(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t)
+	client.FailFast = true
+
+	start := time.Now()
+	_, err := client.Get(server.URL)
+	if err == nil {
+		t.Fatal("Get: expected an error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Get took %s, want FailFast to return immediately instead of sleeping an hour", elapsed)
+	}
+}
+
+func TestGetJSONUsesETagCacheOn304(t *testing.T) {
+	var requests int
+	mux := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"first"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newTestClient(t)
+
+	var first struct{ Name string }
+	if err := client.GetJSON(server.URL, &first); err != nil {
+		t.Fatalf("GetJSON (first): %v", err)
+	}
+	if first.Name != "first" {
+		t.Fatalf("first.Name = %q, want %q", first.Name, "first")
+	}
+
+	var second struct{ Name string }
+	if err := client.GetJSON(server.URL, &second); err != nil {
+		t.Fatalf("GetJSON (second, cached): %v", err)
+	}
+	if second.Name != "first" {
+		t.Fatalf("second.Name = %q, want the cached body %q", second.Name, "first")
+	}
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (the second should have been a conditional 304)", requests)
+	}
+}
+
+func TestGetJSONRefetchesOnETagMiss(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, requests))
+		json.NewEncoder(w).Encode(map[string]int{"n": requests})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t)
+
+	var first map[string]int
+	if err := client.GetJSON(server.URL, &first); err != nil {
+		t.Fatalf("GetJSON (first): %v", err)
+	}
+
+	// The server returns a new ETag on every response and never answers
+	// 304, so every call is a real fetch and the cached body is overwritten
+	// each time.
+	var second map[string]int
+	if err := client.GetJSON(server.URL, &second); err != nil {
+		t.Fatalf("GetJSON (second): %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (no matching ETag, so no 304)", requests)
+	}
+	if first["n"] != 1 || second["n"] != 2 {
+		t.Fatalf("first = %v, second = %v, want distinct fetched bodies", first, second)
+	}
+}
+
+func TestGetJSONCorruptCacheFallsBackToFreshFetch(t *testing.T) {
+	client := newTestClient(t)
+
+	if err := os.WriteFile(client.cachePath, []byte("not json"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"recovered"}`))
+	}))
+	defer server.Close()
+
+	var got struct{ Name string }
+	if err := client.GetJSON(server.URL, &got); err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if got.Name != "recovered" {
+		t.Fatalf("got.Name = %q, want %q", got.Name, "recovered")
+	}
+}
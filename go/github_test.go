@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   map[string]string
+	}{
+		{"empty", "", map[string]string{}},
+		{
+			"single rel",
+			`<https://api.github.com/releases?page=2>; rel="next"`,
+			map[string]string{"next": "https://api.github.com/releases?page=2"},
+		},
+		{
+			"multiple rels",
+			`<https://api.github.com/releases?page=2>; rel="next", <https://api.github.com/releases?page=5>; rel="last"`,
+			map[string]string{
+				"next": "https://api.github.com/releases?page=2",
+				"last": "https://api.github.com/releases?page=5",
+			},
+		},
+		{
+			"extra whitespace around the comma and semicolon",
+			`  <https://api.github.com/releases?page=2> ; rel="next" ,  <https://api.github.com/releases?page=1>; rel="prev"  `,
+			map[string]string{
+				"next": "https://api.github.com/releases?page=2",
+				"prev": "https://api.github.com/releases?page=1",
+			},
+		},
+		{"malformed entry with no semicolon is skipped", `<https://api.github.com/releases?page=2>`, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLinkHeader(tt.header)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseLinkHeader(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+			for rel, url := range tt.want {
+				if got[rel] != url {
+					t.Errorf("parseLinkHeader(%q)[%q] = %q, want %q", tt.header, rel, got[rel], url)
+				}
+			}
+		})
+	}
+}
+
+// withReleasesURL points releasesURL at server for the duration of the test.
+func withReleasesURL(t *testing.T, server *httptest.Server) {
+	t.Helper()
+	original := releasesURL
+	releasesURL = server.URL
+	t.Cleanup(func() { releasesURL = original })
+}
+
+func TestGetReleasesPaginatesUntilNoNextLink(t *testing.T) {
+	pages := [][]GitHubRelease{
+		{{TagName: "page1-a"}, {TagName: "page1-b"}},
+		{{TagName: "page2-a"}},
+	}
+
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.String())
+
+		page := 0
+		if r.URL.Query().Get("page") == "2" {
+			page = 1
+		}
+
+		if page == 0 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, "http://"+r.Host))
+		}
+
+		json.NewEncoder(w).Encode(pages[page])
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	withReleasesURL(t, server)
+
+	releases, err := GetReleases(ReleasesOptions{})
+	if err != nil {
+		t.Fatalf("GetReleases: %v", err)
+	}
+	if len(releases) != 3 {
+		t.Fatalf("len(releases) = %d, want 3: %+v", len(releases), releases)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("server saw %d requests, want 2 (one per page): %v", len(requests), requests)
+	}
+}
+
+func TestGetReleasesHonorsMaxPages(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, "http://"+r.Host+"/?page=next"))
+		json.NewEncoder(w).Encode([]GitHubRelease{{TagName: fmt.Sprintf("page-%d", requests)}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	withReleasesURL(t, server)
+
+	releases, err := GetReleases(ReleasesOptions{MaxPages: 2})
+	if err != nil {
+		t.Fatalf("GetReleases: %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("len(releases) = %d, want 2", len(releases))
+	}
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (MaxPages should stop further fetches)", requests)
+	}
+}
+
+func TestGetReleasesFiltersBySinceAndPredicate(t *testing.T) {
+	old := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]GitHubRelease{
+			{TagName: "too-old", PublishedAt: old},
+			{TagName: "keep-me", PublishedAt: recent},
+			{TagName: "filtered-by-predicate", PublishedAt: recent},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	withReleasesURL(t, server)
+
+	releases, err := GetReleases(ReleasesOptions{
+		Since:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Predicate: func(r GitHubRelease) bool { return r.TagName != "filtered-by-predicate" },
+	})
+	if err != nil {
+		t.Fatalf("GetReleases: %v", err)
+	}
+	if len(releases) != 1 || releases[0].TagName != "keep-me" {
+		t.Fatalf("releases = %+v, want only %q", releases, "keep-me")
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Triple is a parsed LLVM-style target triple, e.g. "x86_64-unknown-linux-gnu"
+// or "aarch64-apple-darwin". Fields beyond what the triple specifies are left
+// empty.
+type Triple struct {
+	Arch   string
+	Vendor string
+	OS     string
+	ABI    string
+}
+
+// ParseTriple splits an Interpreter.Triple string into its components.
+func ParseTriple(triple string) Triple {
+	parts := strings.Split(triple, "-")
+
+	var t Triple
+	if len(parts) > 0 {
+		t.Arch = parts[0]
+	}
+	if len(parts) > 1 {
+		t.Vendor = parts[1]
+	}
+	if len(parts) > 2 {
+		t.OS = parts[2]
+	}
+	if len(parts) > 3 {
+		t.ABI = strings.Join(parts[3:], "-")
+	}
+
+	return t
+}
+
+// osAliases maps OS name variants to the canonical name used for matching.
+var osAliases = map[string]string{
+	"darwin":  "darwin",
+	"macos":   "darwin",
+	"osx":     "darwin",
+	"linux":   "linux",
+	"windows": "windows",
+}
+
+// archAliases maps arch name variants to the canonical name used for matching.
+var archAliases = map[string]string{
+	"x86_64":  "x86_64",
+	"amd64":   "x86_64",
+	"aarch64": "aarch64",
+	"arm64":   "aarch64",
+}
+
+func canonicalOS(os string) string {
+	if canon, ok := osAliases[strings.ToLower(os)]; ok {
+		return canon
+	}
+	return strings.ToLower(os)
+}
+
+func canonicalArch(arch string) string {
+	if canon, ok := archAliases[strings.ToLower(arch)]; ok {
+		return canon
+	}
+	return strings.ToLower(arch)
+}
+
+// HostRequest describes the machine interpreters should be matched against.
+// Leaving OS or Arch empty defaults it to the current runtime.GOOS /
+// runtime.GOARCH.
+type HostRequest struct {
+	OS   string
+	Arch string
+
+	// Libc restricts matches to "glibc" or "musl" on Linux. Empty means no
+	// hard filter, but glibc is still preferred when sorting ties.
+	Libc string
+}
+
+// MatchHost filters interpreters down to the ones matching req's OS and arch
+// (and req.Libc, if set) and sorts the result, best match first: glibc is
+// preferred over musl on Linux unless req.Libc says otherwise, and ByConfig
+// order breaks remaining ties.
+func MatchHost(interpreters []Interpreter, req HostRequest) ([]Interpreter, error) {
+	if req.OS == "" {
+		req.OS = runtime.GOOS
+	}
+	if req.Arch == "" {
+		req.Arch = runtime.GOARCH
+	}
+
+	wantOS := canonicalOS(req.OS)
+	wantArch := canonicalArch(req.Arch)
+
+	matches := make([]Interpreter, 0, len(interpreters))
+	for _, interpreter := range interpreters {
+		triple := ParseTriple(interpreter.Triple)
+
+		if canonicalOS(triple.OS) != wantOS || canonicalArch(triple.Arch) != wantArch {
+			continue
+		}
+
+		if wantOS == "linux" && req.Libc != "" && libcOf(interpreter) != strings.ToLower(req.Libc) {
+			continue
+		}
+
+		matches = append(matches, interpreter)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no interpreters match %s/%s", req.OS, req.Arch)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if wantOS == "linux" {
+			ri, rj := libcRank(libcOf(matches[i]), req.Libc), libcRank(libcOf(matches[j]), req.Libc)
+			if ri != rj {
+				return ri < rj
+			}
+		}
+		return matches[i].Config < matches[j].Config
+	})
+
+	return matches, nil
+}
+
+// libcOf reports the libc an interpreter was built against, preferring the
+// triple's ABI field ("gnu" -> glibc, "musl" -> musl) and falling back to
+// Info.CRTFeatures when the ABI doesn't say. Returns "" when unknown.
+func libcOf(interpreter Interpreter) string {
+	abi := strings.ToLower(ParseTriple(interpreter.Triple).ABI)
+
+	switch {
+	case strings.Contains(abi, "musl"):
+		return "musl"
+	case strings.Contains(abi, "gnu"):
+		return "glibc"
+	}
+
+	for _, feature := range interpreter.Info.CRTFeatures {
+		switch strings.ToLower(feature) {
+		case "musl":
+			return "musl"
+		case "glibc":
+			return "glibc"
+		}
+	}
+
+	return ""
+}
+
+// libcRank orders libc values so that the requested one (or glibc, by
+// default) sorts first.
+func libcRank(libc string, want string) int {
+	target := strings.ToLower(want)
+	if target == "" {
+		target = "glibc"
+	}
+
+	switch {
+	case libc == target:
+		return 0
+	case libc == "":
+		return 1
+	default:
+		return 2
+	}
+}
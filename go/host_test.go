@@ -0,0 +1,182 @@
+package main
+
+import "testing"
+
+func TestParseTriple(t *testing.T) {
+	tests := []struct {
+		triple string
+		want   Triple
+	}{
+		{"x86_64-unknown-linux-gnu", Triple{Arch: "x86_64", Vendor: "unknown", OS: "linux", ABI: "gnu"}},
+		{"aarch64-apple-darwin", Triple{Arch: "aarch64", Vendor: "apple", OS: "darwin"}},
+		{"x86_64-unknown-linux-musl", Triple{Arch: "x86_64", Vendor: "unknown", OS: "linux", ABI: "musl"}},
+		{"x86_64", Triple{Arch: "x86_64"}},
+		{"", Triple{}},
+	}
+
+	for _, tt := range tests {
+		got := ParseTriple(tt.triple)
+		if got != tt.want {
+			t.Errorf("ParseTriple(%q) = %+v, want %+v", tt.triple, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalArchAliases(t *testing.T) {
+	tests := []struct {
+		arch string
+		want string
+	}{
+		{"x86_64", "x86_64"},
+		{"amd64", "x86_64"},
+		{"AMD64", "x86_64"},
+		{"aarch64", "aarch64"},
+		{"arm64", "aarch64"},
+		{"riscv64", "riscv64"}, // unknown alias passes through lowercased
+	}
+
+	for _, tt := range tests {
+		if got := canonicalArch(tt.arch); got != tt.want {
+			t.Errorf("canonicalArch(%q) = %q, want %q", tt.arch, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalOSAliases(t *testing.T) {
+	tests := []struct {
+		os   string
+		want string
+	}{
+		{"darwin", "darwin"},
+		{"macos", "darwin"},
+		{"osx", "darwin"},
+		{"linux", "linux"},
+		{"Windows", "windows"},
+	}
+
+	for _, tt := range tests {
+		if got := canonicalOS(tt.os); got != tt.want {
+			t.Errorf("canonicalOS(%q) = %q, want %q", tt.os, got, tt.want)
+		}
+	}
+}
+
+func glibcInterp(triple string, config Config) Interpreter {
+	return Interpreter{Triple: triple, Config: config}
+}
+
+func TestMatchHostFiltersByOSAndArch(t *testing.T) {
+	interpreters := []Interpreter{
+		glibcInterp("x86_64-unknown-linux-gnu", Empty),
+		glibcInterp("aarch64-apple-darwin", Empty),
+		glibcInterp("arm64-apple-darwin", Empty), // alias of aarch64
+	}
+
+	matches, err := MatchHost(interpreters, HostRequest{OS: "macos", Arch: "arm64"})
+	if err != nil {
+		t.Fatalf("MatchHost: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("len(matches) = %d, want 2", len(matches))
+	}
+	for _, m := range matches {
+		if ParseTriple(m.Triple).OS != "darwin" {
+			t.Errorf("unexpected match %+v", m)
+		}
+	}
+}
+
+func TestMatchHostNoMatchesIsError(t *testing.T) {
+	interpreters := []Interpreter{glibcInterp("x86_64-unknown-linux-gnu", Empty)}
+
+	if _, err := MatchHost(interpreters, HostRequest{OS: "windows", Arch: "x86_64"}); err == nil {
+		t.Fatal("expected error for no matching interpreters, got nil")
+	}
+}
+
+func TestMatchHostPrefersGlibcByDefault(t *testing.T) {
+	interpreters := []Interpreter{
+		glibcInterp("x86_64-unknown-linux-musl", Empty),
+		glibcInterp("x86_64-unknown-linux-gnu", Empty),
+	}
+
+	matches, err := MatchHost(interpreters, HostRequest{OS: "linux", Arch: "x86_64"})
+	if err != nil {
+		t.Fatalf("MatchHost: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("len(matches) = %d, want 2", len(matches))
+	}
+	if libcOf(matches[0]) != "glibc" {
+		t.Fatalf("best match libc = %q, want glibc", libcOf(matches[0]))
+	}
+}
+
+func TestMatchHostHonorsLibcOverride(t *testing.T) {
+	interpreters := []Interpreter{
+		glibcInterp("x86_64-unknown-linux-musl", Empty),
+		glibcInterp("x86_64-unknown-linux-gnu", Empty),
+	}
+
+	matches, err := MatchHost(interpreters, HostRequest{OS: "linux", Arch: "x86_64", Libc: "musl"})
+	if err != nil {
+		t.Fatalf("MatchHost: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("len(matches) = %d, want 1", len(matches))
+	}
+	if libcOf(matches[0]) != "musl" {
+		t.Fatalf("libc = %q, want musl", libcOf(matches[0]))
+	}
+}
+
+func TestMatchHostBreaksTiesByConfig(t *testing.T) {
+	interpreters := []Interpreter{
+		glibcInterp("x86_64-unknown-linux-gnu", Debug),
+		glibcInterp("x86_64-unknown-linux-gnu", PgoLto),
+	}
+
+	matches, err := MatchHost(interpreters, HostRequest{OS: "linux", Arch: "x86_64"})
+	if err != nil {
+		t.Fatalf("MatchHost: %v", err)
+	}
+	if matches[0].Config != PgoLto {
+		t.Fatalf("matches[0].Config = %v, want PgoLto", matches[0].Config)
+	}
+}
+
+func TestLibcOfFallsBackToCRTFeatures(t *testing.T) {
+	interp := Interpreter{
+		Triple: "x86_64-unknown-linux-gnu",
+		Info:   PythonJSON{CRTFeatures: []string{"musl"}},
+	}
+	// The ABI field ("gnu") takes priority over CRTFeatures when present.
+	if got := libcOf(interp); got != "glibc" {
+		t.Fatalf("libcOf = %q, want glibc", got)
+	}
+
+	interp.Triple = "x86_64-unknown-linux"
+	if got := libcOf(interp); got != "musl" {
+		t.Fatalf("libcOf with no ABI = %q, want musl (from CRTFeatures)", got)
+	}
+}
+
+func TestLibcRank(t *testing.T) {
+	tests := []struct {
+		libc string
+		want string
+		rank int
+	}{
+		{"glibc", "", 0},    // default preference is glibc
+		{"musl", "", 2},     // non-matching, known libc ranks last
+		{"", "", 1},         // unknown libc ranks ahead of a known mismatch
+		{"musl", "musl", 0}, // explicit override matched
+		{"glibc", "musl", 2},
+	}
+
+	for _, tt := range tests {
+		if got := libcRank(tt.libc, tt.want); got != tt.rank {
+			t.Errorf("libcRank(%q, %q) = %d, want %d", tt.libc, tt.want, got, tt.rank)
+		}
+	}
+}
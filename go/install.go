@@ -0,0 +1,228 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// InstallOptions controls how Install lays out an interpreter on disk.
+type InstallOptions struct {
+	// BinDir is the root directory installs are placed under.
+	BinDir string
+
+	// Extract, if set, limits extraction to this single path inside the
+	// archive (e.g. "python/install/bin/python3") instead of the full tree.
+	Extract string
+
+	// Force allows overwriting an existing install at the target path.
+	Force bool
+}
+
+// Install downloads interp's archive, verifies its SHA-256 checksum against
+// the sibling *.sha256 asset, and extracts it under
+// <opts.BinDir>/<implementation>-<version>-<triple>/. It returns the path the
+// interpreter was installed to.
+func Install(interp Interpreter, opts InstallOptions) (string, error) {
+	if opts.BinDir == "" {
+		return "", fmt.Errorf("bindir must be set")
+	}
+
+	target := filepath.Join(opts.BinDir, installDirName(interp))
+
+	if _, err := os.Stat(target); err == nil {
+		if !opts.Force {
+			return "", fmt.Errorf("%s already exists, pass --force to overwrite", target)
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to stat %s: %w", target, err)
+	}
+
+	checksum, err := fetchChecksum(interp)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := defaultClient.Get(interp.Url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %q: %w", interp.Url, err)
+	}
+	defer response.Body.Close()
+
+	if err := os.MkdirAll(opts.BinDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", opts.BinDir, err)
+	}
+
+	staging, err := os.MkdirTemp(opts.BinDir, ".install-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	hasher := sha256.New()
+	tee := io.TeeReader(response.Body, hasher)
+	archiveReader, closeArchive, err := newArchiveReader(interp.AssetName, tee)
+	if err != nil {
+		return "", err
+	}
+	defer closeArchive()
+
+	if err := extractTar(archiveReader, staging, opts.Extract); err != nil {
+		return "", err
+	}
+
+	// tar.Reader.Next stops as soon as it sees the archive's logical EOF
+	// (two zero blocks), but a compliant tar writer pads the stream to a
+	// full record boundary beyond that point. Drain whatever's left of the
+	// response body through tee so hasher's sum covers every byte of the
+	// download, not just the bytes extractTar happened to pull through it.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return "", fmt.Errorf("failed to read trailing archive bytes: %w", err)
+	}
+
+	if sum := hex.EncodeToString(hasher.Sum(nil)); sum != checksum {
+		return "", fmt.Errorf("checksum mismatch for %q: got %s, want %s", interp.Url, sum, checksum)
+	}
+
+	if _, err := os.Stat(target); err == nil {
+		if err := os.RemoveAll(target); err != nil {
+			return "", fmt.Errorf("failed to remove existing install at %s: %w", target, err)
+		}
+	}
+
+	if err := os.Rename(staging, target); err != nil {
+		return "", fmt.Errorf("failed to install to %s: %w", target, err)
+	}
+
+	return target, nil
+}
+
+func installDirName(interp Interpreter) string {
+	return fmt.Sprintf("%s-%s-%s", interp.Implementation, interp.PythonVersion, interp.Triple)
+}
+
+// fetchChecksum downloads and parses interp's sibling *.sha256 asset, which
+// python-build-standalone publishes in the usual "<hex>  <filename>" format.
+func fetchChecksum(interp Interpreter) (string, error) {
+	if interp.ChecksumURL == "" {
+		return "", fmt.Errorf("no checksum asset published for %s", interp.AssetName)
+	}
+
+	response, err := defaultClient.Get(interp.ChecksumURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksum for %q: %w", interp.Url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum for %q: %w", interp.Url, err)
+	}
+
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file for %q", interp.Url)
+	}
+
+	return strings.ToLower(fields[0]), nil
+}
+
+// newArchiveReader wraps r with the decompressor matching assetName's
+// extension, returning a close func the caller must call once done.
+func newArchiveReader(assetName string, r io.Reader) (io.Reader, func() error, error) {
+	switch {
+	case strings.HasSuffix(assetName, ".tar.zst"):
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		return decoder, func() error { decoder.Close(); return nil }, nil
+	case strings.HasSuffix(assetName, ".tar.gz"):
+		decoder, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		return decoder, decoder.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported archive format: %s", assetName)
+	}
+}
+
+// extractTar extracts a tar stream into dest, preserving file modes and
+// symlinks. If only is non-empty, just that entry is written to disk, but the
+// rest of the archive is still read through so the caller's checksum over the
+// full stream comes out right.
+func extractTar(r io.Reader, dest string, only string) error {
+	reader := tar.NewReader(r)
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		if only != "" && header.Name != only {
+			continue
+		}
+
+		path, err := safeJoin(dest, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, os.FileMode(header.Mode)); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", path, err)
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+			}
+			if err := os.Symlink(header.Linkname, path); err != nil {
+				return fmt.Errorf("failed to create symlink %s: %w", path, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+			}
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return fmt.Errorf("failed to create file %s: %w", path, err)
+			}
+			if _, err := io.Copy(file, reader); err != nil {
+				file.Close()
+				return fmt.Errorf("failed to write file %s: %w", path, err)
+			}
+			file.Close()
+		default:
+			continue
+		}
+	}
+
+	return nil
+}
+
+// safeJoin joins name onto dest, rejecting archive entries that would escape
+// dest via ".." path segments.
+func safeJoin(dest string, name string) (string, error) {
+	cleanDest := filepath.Clean(dest)
+	path := filepath.Join(cleanDest, filepath.Clean(string(filepath.Separator)+name))
+
+	if path != cleanDest && !strings.HasPrefix(path, cleanDest+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path in archive: %s", name)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// buildTarZst builds a tar+zstd archive containing a single file, padded
+// with trailing zero bytes past the tar format's own end-of-archive marker
+// (two zero blocks) — the way real tar writers pad to a full record, and the
+// case that broke checksum verification in Install.
+func buildTarZst(t *testing.T, name, content string, trailingPadding int) []byte {
+	t.Helper()
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close: %v", err)
+	}
+
+	tarBuf.Write(make([]byte, trailingPadding))
+
+	var zstBuf bytes.Buffer
+	zw, err := zstd.NewWriter(&zstBuf)
+	if err != nil {
+		t.Fatalf("zstd.NewWriter: %v", err)
+	}
+	if _, err := zw.Write(tarBuf.Bytes()); err != nil {
+		t.Fatalf("zstd Write: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zstd Close: %v", err)
+	}
+
+	return zstBuf.Bytes()
+}
+
+func TestInstallVerifiesChecksumWithTrailingPadding(t *testing.T) {
+	archive := buildTarZst(t, "python/install/bin/python3", "#!/bin/sh\necho fake\n", 1024)
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cpython.tar.zst", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	mux.HandleFunc("/cpython.tar.zst.sha256", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(checksum + "  cpython.tar.zst\n"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	interp := Interpreter{
+		Implementation: "cpython",
+		PythonVersion:  "3.12.2",
+		Triple:         "x86_64-unknown-linux-gnu",
+		Url:            server.URL + "/cpython.tar.zst",
+		AssetName:      "cpython.tar.zst",
+		ChecksumURL:    server.URL + "/cpython.tar.zst.sha256",
+	}
+
+	bindir := t.TempDir()
+	target, err := Install(interp, InstallOptions{BinDir: bindir})
+	if err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(target, "python/install/bin/python3"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != "#!/bin/sh\necho fake\n" {
+		t.Fatalf("extracted content = %q", content)
+	}
+}
+
+func TestInstallRejectsChecksumMismatch(t *testing.T) {
+	archive := buildTarZst(t, "python/install/bin/python3", "#!/bin/sh\necho fake\n", 512)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cpython.tar.zst", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	mux.HandleFunc("/cpython.tar.zst.sha256", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0000000000000000000000000000000000000000000000000000000000000000  cpython.tar.zst\n"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	interp := Interpreter{
+		Implementation: "cpython",
+		PythonVersion:  "3.12.2",
+		Triple:         "x86_64-unknown-linux-gnu",
+		Url:            server.URL + "/cpython.tar.zst",
+		AssetName:      "cpython.tar.zst",
+		ChecksumURL:    server.URL + "/cpython.tar.zst.sha256",
+	}
+
+	bindir := t.TempDir()
+	if _, err := Install(interp, InstallOptions{BinDir: bindir}); err == nil {
+		t.Fatal("expected checksum mismatch error, got nil")
+	}
+
+	// A failed install must not leave a partial install behind.
+	target := filepath.Join(bindir, installDirName(interp))
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Fatalf("target %s should not exist after a failed install, stat err = %v", target, err)
+	}
+}
+
+func TestInstallForceReplacesExistingOnlyAfterVerification(t *testing.T) {
+	archive := buildTarZst(t, "python/install/bin/python3", "new content\n", 256)
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cpython.tar.zst", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	mux.HandleFunc("/cpython.tar.zst.sha256", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(checksum + "  cpython.tar.zst\n"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	interp := Interpreter{
+		Implementation: "cpython",
+		PythonVersion:  "3.12.2",
+		Triple:         "x86_64-unknown-linux-gnu",
+		Url:            server.URL + "/cpython.tar.zst",
+		AssetName:      "cpython.tar.zst",
+		ChecksumURL:    server.URL + "/cpython.tar.zst.sha256",
+	}
+
+	bindir := t.TempDir()
+	target := filepath.Join(bindir, installDirName(interp))
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	marker := filepath.Join(target, "old-marker")
+	if err := os.WriteFile(marker, []byte("old"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := Install(interp, InstallOptions{BinDir: bindir, Force: true}); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatalf("old install should have been replaced, marker stat err = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(target, "python/install/bin/python3"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != "new content\n" {
+		t.Fatalf("extracted content = %q", content)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"sort"
 	"strings"
 
@@ -27,17 +26,32 @@ type PythonJSON struct {
 }
 
 type Interpreter struct {
-	Implementation         string
-	PythonVersion          string
-	GitHubRelease          string
-	Triple                 string
-	Config                 Config
-	Flavor                 InterpreterFlavor
-	Url                    string
+	Implementation string
+	PythonVersion  string
+	GitHubRelease  string
+	Triple         string
+	Config         Config
+	Flavor         InterpreterFlavor
+	Url            string
+	// AssetName is the file name of the release asset Url points to, e.g.
+	// "cpython-3.12.2+20240415-x86_64-unknown-linux-gnu-pgo+lto-full.tar.zst".
+	AssetName string
+	// ChecksumURL is the URL of the sibling *.sha256 asset, when published.
+	ChecksumURL            string
 	Info                   PythonJSON
 	InterpreterImplemented *Interpreter
 }
 
+// findAsset looks up a release asset by exact file name.
+func findAsset(release GitHubRelease, name string) (GitHubReleaseAsset, bool) {
+	for _, asset := range release.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return GitHubReleaseAsset{}, false
+}
+
 func (i Interpreter) GetKey() string {
 	return fmt.Sprintf("%s-%s+%s-%s", i.Implementation, i.PythonVersion, i.GitHubRelease, i.Triple)
 }
@@ -49,26 +63,44 @@ func GetBestInterpreter(interpreters []Interpreter) *Interpreter {
 	return &interpreters[0]
 }
 
-func GetInterpreters(release GitHubRelease, threads int) ([]Interpreter, error) {
+// GetInterpreters collects every interpreter found across releases, which may
+// be a single release or a full history obtained from GetReleases. Assets are
+// deduped by Interpreter.GetKey() plus the release they came from, so calling
+// this with overlapping releases (e.g. re-fetching with a wider Since) is
+// safe.
+func GetInterpreters(threads int, releases ...GitHubRelease) ([]Interpreter, error) {
 	groups := map[string][]Interpreter{}
 	installOnlyInterpreters := []Interpreter{}
+	seen := map[string]bool{}
 
-	for _, asset := range release.Assets {
-		if !strings.HasSuffix(asset.Name, ".tar.zst") && !strings.HasSuffix(asset.Name, ".tar.gz") {
-			continue
-		}
+	for _, release := range releases {
+		for _, asset := range release.Assets {
+			if !strings.HasSuffix(asset.Name, ".tar.zst") && !strings.HasSuffix(asset.Name, ".tar.gz") {
+				continue
+			}
 
-		interpreter, err := parseAsset(asset)
-		if err != nil {
-			return installOnlyInterpreters, fmt.Errorf("failed to parse asset %s: %w", asset.Name, err)
-		}
+			interpreter, err := parseAsset(asset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse asset %s: %w", asset.Name, err)
+			}
+
+			if checksumAsset, ok := findAsset(release, asset.Name+".sha256"); ok {
+				interpreter.ChecksumURL = checksumAsset.BrowserDownloadURL
+			}
 
-		switch interpreter.Flavor {
-		case FlavorInstallOnly:
-			installOnlyInterpreters = append(installOnlyInterpreters, interpreter)
-		case FlavorFull:
-			key := interpreter.GetKey()
-			groups[key] = append(groups[key], interpreter)
+			key := interpreter.GetKey() + "+" + release.TagName
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			switch interpreter.Flavor {
+			case FlavorInstallOnly:
+				installOnlyInterpreters = append(installOnlyInterpreters, interpreter)
+			case FlavorFull:
+				groupKey := interpreter.GetKey()
+				groups[groupKey] = append(groups[groupKey], interpreter)
+			}
 		}
 	}
 
@@ -140,12 +172,25 @@ func GetInterpreters(release GitHubRelease, threads int) ([]Interpreter, error)
 	return interpreters, nil
 }
 
-// GetPythonInfo reads the python/PYTHON.json file inside an archive. The content
-// is streamed and only the necessary bits are read.
+// GetPythonInfo reads the python/PYTHON.json file inside an archive. When the
+// archive is hosted on a server that supports byte range requests, it tries
+// a fast path that only downloads the zstd frames containing PYTHON.json
+// instead of streaming and decompressing the whole archive; otherwise (or on
+// any error from that path) it falls back to streaming the full archive.
 func GetPythonInfo(url string) (PythonJSON, error) {
+	if pythonJSON, ok, err := getPythonInfoFast(url); err == nil && ok {
+		return pythonJSON, nil
+	}
+
+	return getPythonInfoFull(url)
+}
+
+// getPythonInfoFull streams the whole archive and reads python/PYTHON.json
+// out of it sequentially. This is the reliable, if slower, fallback.
+func getPythonInfoFull(url string) (PythonJSON, error) {
 	var pythonJSON PythonJSON
 
-	response, err := http.Get(url)
+	response, err := defaultClient.Get(url)
 	if err != nil {
 		return pythonJSON, fmt.Errorf("failed to query %q: %w", url, err)
 	}
@@ -0,0 +1,125 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Constraint is a single bound on a Version, e.g. ">=3.10.0" or "~3.11".
+type Constraint struct {
+	Op      string // one of ">=", "<=", ">", "<", "==", "~", or "" (bare version, same as "==")
+	Version Version
+
+	// Precision is how many dotted components were given in the constraint
+	// (1, 2, or 3); "==3.12.*" only constrains Major and Minor.
+	Precision int
+}
+
+var constraintRegex = regexp.MustCompile(`^(>=|<=|==|~|>|<)?(.+)$`)
+
+// ParseConstraints splits a space-separated range spec such as
+// ">=3.10.0 <3.13" into individual constraints, every one of which must match
+// for a version to satisfy the spec.
+func ParseConstraints(spec string) ([]Constraint, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty version spec")
+	}
+
+	constraints := make([]Constraint, 0, len(fields))
+	for _, field := range fields {
+		constraint, err := parseConstraint(field)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
+
+func parseConstraint(token string) (Constraint, error) {
+	matches := constraintRegex.FindStringSubmatch(token)
+	if matches == nil {
+		return Constraint{}, fmt.Errorf("invalid version constraint: %q", token)
+	}
+
+	op := matches[1]
+	versionStr := matches[2]
+
+	if wildcard := strings.HasSuffix(versionStr, ".*"); wildcard {
+		versionStr = strings.TrimSuffix(versionStr, ".*")
+		if op == "" {
+			op = "=="
+		}
+	}
+
+	version, precision, err := parsePartial(versionStr)
+	if err != nil {
+		return Constraint{}, fmt.Errorf("invalid version constraint %q: %w", token, err)
+	}
+
+	return Constraint{Op: op, Version: version, Precision: precision}, nil
+}
+
+// Matches reports whether v satisfies the constraint.
+func (c Constraint) Matches(v Version) bool {
+	switch c.Op {
+	case ">=":
+		return Compare(v, c.Version) >= 0
+	case ">":
+		return Compare(v, c.Version) > 0
+	case "<=":
+		return Compare(v, c.Version) <= 0
+	case "<":
+		return Compare(v, c.Version) < 0
+	case "~":
+		return c.matchesTilde(v)
+	case "==", "":
+		return comparePartial(v, c.Version, c.Precision) == 0
+	default:
+		return false
+	}
+}
+
+// matchesTilde implements "~3.11" (allow patch-level changes: >=3.11.0
+// <3.12.0) and "~3" (allow minor-level changes: >=3.0.0 <4.0.0).
+func (c Constraint) matchesTilde(v Version) bool {
+	lower := c.Version
+
+	upper := Version{Major: c.Version.Major, Minor: c.Version.Minor + 1}
+	if c.Precision == 1 {
+		upper = Version{Major: c.Version.Major + 1}
+	}
+
+	return Compare(v, lower) >= 0 && Compare(v, upper) < 0
+}
+
+// comparePartial compares a and b but only down to precision components,
+// which lets "==3.12.*" (precision 2) match any patch version.
+func comparePartial(a, b Version, precision int) int {
+	if c := compareInt(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if precision < 2 {
+		return 0
+	}
+	if c := compareInt(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if precision < 3 {
+		return 0
+	}
+	return compareInt(a.Patch, b.Patch)
+}
+
+// MatchAll reports whether v satisfies every constraint.
+func MatchAll(constraints []Constraint, v Version) bool {
+	for _, constraint := range constraints {
+		if !constraint.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
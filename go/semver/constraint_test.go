@@ -0,0 +1,118 @@
+package semver
+
+import "testing"
+
+func mustParse(t *testing.T, s string) Version {
+	t.Helper()
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return v
+}
+
+func TestConstraintMatchesTilde(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"~3.11", "3.11.0", true},
+		{"~3.11", "3.11.9", true},
+		{"~3.11", "3.12.0", false},
+		{"~3.11", "3.10.9", false},
+		{"~3", "3.0.0", true},
+		{"~3", "3.99.0", true},
+		{"~3", "4.0.0", false},
+	}
+
+	for _, tt := range tests {
+		constraints, err := ParseConstraints(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraints(%q): %v", tt.constraint, err)
+		}
+		got := constraints[0].Matches(mustParse(t, tt.version))
+		if got != tt.want {
+			t.Errorf("%q.Matches(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintWildcardWithExplicitOperator(t *testing.T) {
+	// ">=3.12.*" should keep its ">=" operator rather than silently
+	// collapsing to "==3.12.*"; the ".*" just means "don't pin the patch".
+	constraints, err := ParseConstraints(">=3.12.*")
+	if err != nil {
+		t.Fatalf("ParseConstraints: %v", err)
+	}
+	if constraints[0].Op != ">=" {
+		t.Fatalf("Op = %q, want %q", constraints[0].Op, ">=")
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"3.11.9", false},
+		{"3.12.0", true},
+		{"3.12.5", true},
+		{"3.13.0", true},
+	}
+
+	for _, tt := range tests {
+		got := constraints[0].Matches(mustParse(t, tt.version))
+		if got != tt.want {
+			t.Errorf(">=3.12.*.Matches(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintBareWildcard(t *testing.T) {
+	constraints, err := ParseConstraints("==3.12.*")
+	if err != nil {
+		t.Fatalf("ParseConstraints: %v", err)
+	}
+	if constraints[0].Op != "==" {
+		t.Fatalf("Op = %q, want %q", constraints[0].Op, "==")
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"3.12.0", true},
+		{"3.12.5", true},
+		{"3.13.0", false},
+	}
+
+	for _, tt := range tests {
+		got := constraints[0].Matches(mustParse(t, tt.version))
+		if got != tt.want {
+			t.Errorf("==3.12.*.Matches(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestMatchAllRange(t *testing.T) {
+	constraints, err := ParseConstraints(">=3.10.0 <3.13")
+	if err != nil {
+		t.Fatalf("ParseConstraints: %v", err)
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"3.9.9", false},
+		{"3.10.0", true},
+		{"3.12.9", true},
+		{"3.13.0", false},
+	}
+
+	for _, tt := range tests {
+		got := MatchAll(constraints, mustParse(t, tt.version))
+		if got != tt.want {
+			t.Errorf("MatchAll(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
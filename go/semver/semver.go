@@ -0,0 +1,102 @@
+// Package semver implements just enough of semantic versioning to filter
+// Python interpreter builds: parsing MAJOR[.MINOR[.PATCH]] versions with an
+// optional prerelease suffix, and matching them against simple range
+// constraints such as ">=3.10.0 <3.13", "~3.11", or "==3.12.*".
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed MAJOR.MINOR.PATCH version with an optional prerelease.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Parse parses a full or partial dotted version, e.g. "3.12.2" or "3.12",
+// optionally followed by "-<prerelease>". Missing components default to 0.
+func Parse(s string) (Version, error) {
+	v, _, err := parsePartial(s)
+	return v, err
+}
+
+// parsePartial parses s and additionally reports how many dotted components
+// were given (1, 2, or 3), which Constraint needs to implement "==3.12.*"
+// style wildcard matching.
+func parsePartial(s string) (Version, int, error) {
+	var v Version
+
+	if idx := strings.IndexByte(s, '-'); idx >= 0 {
+		v.Prerelease = s[idx+1:]
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid version: %q", s)
+	}
+
+	fields := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("invalid version component %q in %q: %w", part, s, err)
+		}
+		*fields[i] = n
+	}
+
+	return v, len(parts), nil
+}
+
+// Compare returns -1, 0, or 1 depending on whether a is less than, equal to,
+// or greater than b. A version without a prerelease is greater than the same
+// version with one, per semver precedence rules.
+func Compare(a, b Version) int {
+	if c := compareInt(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
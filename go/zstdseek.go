@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JeanChristopheMorinPerso/rez-bootstrap/github"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Constants from the zstd seekable format
+// (https://github.com/facebook/zstd/blob/dev/contrib/seekable_format/zstd_seekable_compression_format.md).
+const (
+	seekableMagicNumber   = 0x8F92EAB1
+	skippableFrameMagic   = 0x184D2A5E
+	seekTableFooterSize   = 9
+	seekTableEntrySize    = 8 // Compressed_Size + Decompressed_Size, each uint32
+	seekTableChecksumSize = 4
+	tarBlockSize          = 512
+)
+
+// maxFramesFromEnd bounds how many trailing frames getPythonInfoFast will
+// decompress looking for python/PYTHON.json before giving up and falling
+// back to the full-stream reader.
+const maxFramesFromEnd = 8
+
+// pythonJSONName is the tar member GetPythonInfo looks for.
+const pythonJSONName = "python/PYTHON.json"
+
+type seekTableEntry struct {
+	CompressedSize   uint32
+	DecompressedSize uint32
+}
+
+type seekTable struct {
+	entries   []seekTableEntry
+	frameSize int64 // size in bytes of the whole skippable seek-table frame, header included
+}
+
+// httpReaderAt is an io.ReaderAt backed by HTTP Range requests against url.
+type httpReaderAt struct {
+	client *github.Client
+	url    string
+}
+
+func (r *httpReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	rangeHeader := fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1)
+
+	response, err := r.client.GetRange(r.url, rangeHeader)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusPartialContent {
+		return 0, fmt.Errorf("server did not honor range request for %q: %s", r.url, response.Status)
+	}
+
+	return io.ReadFull(response.Body, p)
+}
+
+// probeSeekable issues a HEAD request to check whether url supports byte
+// range requests, returning its total size if so.
+func probeSeekable(url string) (int64, bool, error) {
+	response, err := defaultClient.Head(url)
+	if err != nil {
+		return 0, false, err
+	}
+	defer response.Body.Close()
+
+	if !strings.Contains(response.Header.Get("Accept-Ranges"), "bytes") {
+		return 0, false, nil
+	}
+
+	size, err := strconv.ParseInt(response.Header.Get("Content-Length"), 10, 64)
+	if err != nil || size <= 0 {
+		return 0, false, nil
+	}
+
+	return size, true, nil
+}
+
+// readSeekTable reads and parses the zstd seekable format's trailing seek
+// table, which records the compressed/decompressed size of every frame.
+func readSeekTable(reader io.ReaderAt, size int64) (*seekTable, error) {
+	footer := make([]byte, seekTableFooterSize)
+	if _, err := reader.ReadAt(footer, size-seekTableFooterSize); err != nil {
+		return nil, fmt.Errorf("failed to read seek table footer: %w", err)
+	}
+
+	if binary.LittleEndian.Uint32(footer[5:9]) != seekableMagicNumber {
+		return nil, fmt.Errorf("archive is not zstd-seekable")
+	}
+
+	numFrames := binary.LittleEndian.Uint32(footer[0:4])
+	hasChecksums := footer[4]&0x80 != 0
+
+	entrySize := seekTableEntrySize
+	if hasChecksums {
+		entrySize += seekTableChecksumSize
+	}
+
+	entriesSize := int64(numFrames) * int64(entrySize)
+	frameContentSize := entriesSize + seekTableFooterSize
+	frameSize := 8 + frameContentSize // 8-byte skippable frame header (magic + size)
+
+	// The seek table can't be bigger than the archive itself; reject a
+	// corrupt or unexpectedly-served footer before trusting numFrames for
+	// an allocation.
+	if frameSize <= 0 || frameSize > size {
+		return nil, fmt.Errorf("implausible seek table frame size: %d", frameSize)
+	}
+
+	header := make([]byte, 8)
+	if _, err := reader.ReadAt(header, size-frameSize); err != nil {
+		return nil, fmt.Errorf("failed to read seek table frame header: %w", err)
+	}
+
+	if binary.LittleEndian.Uint32(header[0:4]) != skippableFrameMagic {
+		return nil, fmt.Errorf("seek table frame header has unexpected magic number")
+	}
+	if int64(binary.LittleEndian.Uint32(header[4:8])) != frameContentSize {
+		return nil, fmt.Errorf("seek table frame header size mismatch")
+	}
+
+	entries := make([]byte, entriesSize)
+	if _, err := reader.ReadAt(entries, size-frameSize+8); err != nil {
+		return nil, fmt.Errorf("failed to read seek table entries: %w", err)
+	}
+
+	table := &seekTable{entries: make([]seekTableEntry, numFrames), frameSize: frameSize}
+	for i := range table.entries {
+		offset := i * entrySize
+		table.entries[i] = seekTableEntry{
+			CompressedSize:   binary.LittleEndian.Uint32(entries[offset : offset+4]),
+			DecompressedSize: binary.LittleEndian.Uint32(entries[offset+4 : offset+8]),
+		}
+	}
+
+	return table, nil
+}
+
+// decompressFrame decompresses data, which may hold one or more concatenated
+// zstd frames.
+func decompressFrame(data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+	}
+	defer decoder.Close()
+
+	return io.ReadAll(decoder)
+}
+
+// findTarEntry scans decompressed tar bytes, which start at decompressedOffset
+// bytes into the overall archive, for a header naming target, and returns its
+// content. It only recognizes headers that land on a 512-byte tar block
+// boundary relative to the start of the archive, since that's what lets us
+// trust a name match found in an arbitrary byte slice.
+func findTarEntry(data []byte, decompressedOffset int64, target string) ([]byte, bool) {
+	needle := []byte(target)
+
+	for searchFrom := 0; ; {
+		pos := bytes.Index(data[searchFrom:], needle)
+		if pos < 0 {
+			return nil, false
+		}
+		pos += searchFrom
+		searchFrom = pos + 1
+
+		if (decompressedOffset+int64(pos))%tarBlockSize != 0 {
+			continue
+		}
+
+		if pos+tarBlockSize > len(data) {
+			continue
+		}
+
+		header := data[pos : pos+tarBlockSize]
+		name := string(bytes.TrimRight(header[0:100], "\x00"))
+		if name != target {
+			continue
+		}
+
+		contentSize, err := parseOctalField(header[124:136])
+		if err != nil {
+			continue
+		}
+
+		contentStart := pos + tarBlockSize
+		contentEnd := contentStart + int(contentSize)
+		if contentEnd > len(data) {
+			continue
+		}
+
+		return data[contentStart:contentEnd], true
+	}
+}
+
+// parseOctalField parses a tar header's nul/space-padded octal numeric field.
+func parseOctalField(field []byte) (int64, error) {
+	trimmed := strings.TrimRight(strings.TrimLeft(string(field), " "), " \x00")
+	return strconv.ParseInt(trimmed, 8, 64)
+}
+
+// getPythonInfoFast attempts to read python/PYTHON.json without streaming the
+// whole archive: it locates the archive's zstd seek table via a suffix Range
+// request, then decompresses a growing window of trailing frames (PYTHON.json
+// sits near the end of these archives) looking for the entry. Frames are
+// decompressed together rather than one at a time, because zstd decodes
+// concatenated frames transparently and PYTHON.json's tar header or content
+// can straddle a frame boundary. ok is false whenever the fast path can't be
+// used or doesn't find the entry within maxFramesFromEnd frames, in which
+// case the caller should fall back to the full-stream reader.
+func getPythonInfoFast(url string) (pythonJSON PythonJSON, ok bool, err error) {
+	size, seekable, err := probeSeekable(url)
+	if err != nil || !seekable {
+		return pythonJSON, false, err
+	}
+
+	reader := &httpReaderAt{client: defaultClient, url: url}
+
+	table, err := readSeekTable(reader, size)
+	if err != nil {
+		return pythonJSON, false, err
+	}
+
+	totalDecompressed := int64(0)
+	for _, entry := range table.entries {
+		totalDecompressed += int64(entry.DecompressedSize)
+	}
+
+	numFrames := len(table.entries)
+	framesToTry := maxFramesFromEnd
+	if framesToTry > numFrames {
+		framesToTry = numFrames
+	}
+
+	// buf accumulates the trailing window across iterations. Each iteration
+	// only fetches the newly-widened slice and prepends it, rather than
+	// re-requesting bytes an earlier, narrower window already pulled.
+	var buf []byte
+	fetchedFrom := size - table.frameSize
+
+	for windowSize := 1; windowSize <= framesToTry; windowSize++ {
+		startIdx := numFrames - windowSize
+
+		compressedOffset := size - table.frameSize
+		decompressedOffset := totalDecompressed
+		for _, entry := range table.entries[startIdx:] {
+			compressedOffset -= int64(entry.CompressedSize)
+			decompressedOffset -= int64(entry.DecompressedSize)
+		}
+
+		windowBytes := size - table.frameSize - compressedOffset
+		if windowBytes <= 0 || windowBytes > size {
+			return pythonJSON, false, fmt.Errorf("implausible seek table window size: %d", windowBytes)
+		}
+
+		if compressedOffset < fetchedFrom {
+			delta := make([]byte, fetchedFrom-compressedOffset)
+			if _, err := reader.ReadAt(delta, compressedOffset); err != nil {
+				return pythonJSON, false, err
+			}
+			buf = append(delta, buf...)
+			fetchedFrom = compressedOffset
+		}
+
+		decompressed, err := decompressFrame(buf)
+		if err != nil {
+			return pythonJSON, false, err
+		}
+
+		content, found := findTarEntry(decompressed, decompressedOffset, pythonJSONName)
+		if !found {
+			continue
+		}
+
+		if err := json.Unmarshal(content, &pythonJSON); err != nil {
+			return pythonJSON, false, fmt.Errorf("failed to decode %s for %q: %w", pythonJSONName, url, err)
+		}
+
+		return pythonJSON, true, nil
+	}
+
+	return pythonJSON, false, nil
+}
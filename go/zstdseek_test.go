@@ -0,0 +1,251 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// buildSeekableArchive compresses each element of chunks as its own
+// independent zstd frame and appends a zstd-seekable seek table (no
+// checksums) describing them, mirroring what python-build-standalone
+// publishes.
+func buildSeekableArchive(t *testing.T, chunks [][]byte) []byte {
+	t.Helper()
+
+	var archive bytes.Buffer
+	entries := make([]seekTableEntry, len(chunks))
+
+	for i, chunk := range chunks {
+		var frame bytes.Buffer
+		w, err := zstd.NewWriter(&frame)
+		if err != nil {
+			t.Fatalf("zstd.NewWriter: %v", err)
+		}
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatalf("zstd Write: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("zstd Close: %v", err)
+		}
+
+		entries[i] = seekTableEntry{
+			CompressedSize:   uint32(frame.Len()),
+			DecompressedSize: uint32(len(chunk)),
+		}
+		archive.Write(frame.Bytes())
+	}
+
+	entriesSize := len(entries) * seekTableEntrySize
+	frameContentSize := entriesSize + seekTableFooterSize
+
+	header := make([]byte, 8)
+	binary.LittleEndian.PutUint32(header[0:4], skippableFrameMagic)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(frameContentSize))
+	archive.Write(header)
+
+	for _, entry := range entries {
+		var buf [seekTableEntrySize]byte
+		binary.LittleEndian.PutUint32(buf[0:4], entry.CompressedSize)
+		binary.LittleEndian.PutUint32(buf[4:8], entry.DecompressedSize)
+		archive.Write(buf[:])
+	}
+
+	footer := make([]byte, seekTableFooterSize)
+	binary.LittleEndian.PutUint32(footer[0:4], uint32(len(entries)))
+	footer[4] = 0 // no per-frame checksums
+	binary.LittleEndian.PutUint32(footer[5:9], seekableMagicNumber)
+	archive.Write(footer)
+
+	return archive.Bytes()
+}
+
+// tarEntry builds a single tar header+content, padded to a 512-byte block
+// boundary, the unit buildSeekableArchive's caller splits into frames.
+func tarEntry(t *testing.T, name, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close: %v", err)
+	}
+
+	// tar.Writer.Close pads with the two zero end-of-archive blocks; strip
+	// those so entries can be concatenated across frames and still line up
+	// on tarBlockSize boundaries.
+	return buf.Bytes()[:len(buf.Bytes())-2*tarBlockSize]
+}
+
+func TestReadSeekTableRoundTrip(t *testing.T) {
+	first := tarEntry(t, "python/install/lib/filler.txt", strings.Repeat("x", 600))
+	second := tarEntry(t, pythonJSONName, `{"crt_features":[]}`)
+
+	archive := buildSeekableArchive(t, [][]byte{first, second})
+
+	table, err := readSeekTable(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("readSeekTable: %v", err)
+	}
+
+	if len(table.entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(table.entries))
+	}
+	if int(table.entries[0].DecompressedSize) != len(first) {
+		t.Fatalf("entries[0].DecompressedSize = %d, want %d", table.entries[0].DecompressedSize, len(first))
+	}
+	if int(table.entries[1].DecompressedSize) != len(second) {
+		t.Fatalf("entries[1].DecompressedSize = %d, want %d", table.entries[1].DecompressedSize, len(second))
+	}
+}
+
+func TestReadSeekTableRejectsImplausibleFrameSize(t *testing.T) {
+	// A handful of bytes can't possibly contain a valid seek table footer
+	// claiming thousands of frames; readSeekTable must reject it instead of
+	// trying to allocate/read past the start of the buffer.
+	footer := make([]byte, seekTableFooterSize)
+	binary.LittleEndian.PutUint32(footer[0:4], 1<<20)
+	binary.LittleEndian.PutUint32(footer[5:9], seekableMagicNumber)
+
+	if _, err := readSeekTable(bytes.NewReader(footer), int64(len(footer))); err == nil {
+		t.Fatal("expected error for implausible seek table frame size, got nil")
+	}
+}
+
+func TestGetPythonInfoFastFindsEntryWithoutRefetching(t *testing.T) {
+	filler := tarEntry(t, "python/install/lib/filler.txt", strings.Repeat("x", 4096))
+	target := tarEntry(t, pythonJSONName, `{"crt_features":["cet"]}`)
+
+	archive := buildSeekableArchive(t, [][]byte{filler, target})
+
+	var rangeRequests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/archive.tar.zst", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+			return
+		}
+
+		rangeHeader := r.Header.Get("Range")
+		rangeRequests = append(rangeRequests, rangeHeader)
+
+		var start, end int
+		if _, err := fmtSscanRange(rangeHeader, &start, &end); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if end >= len(archive) {
+			end = len(archive) - 1
+		}
+
+		w.Header().Set("Content-Range", rangeHeader)
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(archive[start : end+1])
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	info, ok, err := getPythonInfoFast(server.URL + "/archive.tar.zst")
+	if err != nil {
+		t.Fatalf("getPythonInfoFast: %v", err)
+	}
+	if !ok {
+		t.Fatal("getPythonInfoFast: ok = false, want true")
+	}
+	if len(info.CRTFeatures) != 1 || info.CRTFeatures[0] != "cet" {
+		t.Fatalf("info.CRTFeatures = %v", info.CRTFeatures)
+	}
+
+	// Each widened window must only fetch the newly-added bytes, never
+	// re-requesting a range already covered by a narrower prior window.
+	seen := make(map[string]bool)
+	for _, rng := range rangeRequests {
+		if seen[rng] {
+			t.Fatalf("range %q was requested more than once", rng)
+		}
+		seen[rng] = true
+	}
+}
+
+func TestGetPythonInfoFastRejectsCorruptSeekTableEntry(t *testing.T) {
+	filler := tarEntry(t, "python/install/lib/filler.txt", strings.Repeat("x", 4096))
+	target := tarEntry(t, pythonJSONName, `{"crt_features":["cet"]}`)
+
+	archive := buildSeekableArchive(t, [][]byte{filler, target})
+
+	// Corrupt the last entry's CompressedSize so it's far larger than the
+	// archive itself; this drives compressedOffset negative and windowBytes
+	// past size, which must be rejected before an allocation is attempted.
+	footerStart := len(archive) - seekTableFooterSize
+	numFrames := binary.LittleEndian.Uint32(archive[footerStart : footerStart+4])
+	frameContentSize := int(numFrames)*seekTableEntrySize + seekTableFooterSize
+	frameStart := len(archive) - 8 - frameContentSize
+	lastEntryOffset := frameStart + 8 + int(numFrames-1)*seekTableEntrySize
+	binary.LittleEndian.PutUint32(archive[lastEntryOffset:lastEntryOffset+4], 0xFFFFFFFF)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/archive.tar.zst", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Accept-Ranges", "bytes")
+			w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+			return
+		}
+
+		var start, end int
+		if _, err := fmtSscanRange(r.Header.Get("Range"), &start, &end); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if end >= len(archive) {
+			end = len(archive) - 1
+		}
+		if start < 0 || start > end {
+			http.Error(w, "invalid range", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+
+		w.Header().Set("Content-Range", r.Header.Get("Range"))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(archive[start : end+1])
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	_, ok, err := getPythonInfoFast(server.URL + "/archive.tar.zst")
+	if err == nil {
+		t.Fatal("expected error for corrupt seek table entry, got nil")
+	}
+	if ok {
+		t.Fatal("ok = true, want false")
+	}
+}
+
+// fmtSscanRange parses a "bytes=start-end" Range header.
+func fmtSscanRange(header string, start, end *int) (int, error) {
+	header = strings.TrimPrefix(header, "bytes=")
+	parts := strings.SplitN(header, "-", 2)
+	s, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	e, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err
+	}
+	*start, *end = s, e
+	return 2, nil
+}